@@ -0,0 +1,32 @@
+package ezdb
+
+// BackendKind selects which storage engine a DB is backed by.
+type BackendKind int
+
+const (
+	// BackendLevigo backs a DB with github.com/jmhodges/levigo, a cgo
+	// binding of the original C++ leveldb. This is the default and
+	// requires a C toolchain and libleveldb to build.
+	BackendLevigo BackendKind = iota
+
+	// BackendGoLevelDB backs a DB with github.com/syndtr/goleveldb, a
+	// pure Go leveldb implementation. It needs no cgo and can recover
+	// automatically from a corrupted database directory.
+	BackendGoLevelDB
+)
+
+// backend is the storage engine interface implemented by each supported
+// backend. DB's typed helpers (PutStr, GetInt64, Range, ...) are built on
+// top of this interface once, so they work the same regardless of which
+// backend a DB was opened with.
+type backend interface {
+	Put(key, value []byte) error
+	PutSync(key, value []byte) error
+	Get(key []byte) (value []byte, err error)
+	Delete(key []byte) error
+	DeleteSync(key []byte) error
+	Write(b *Batch) error
+	WriteSync(b *Batch) error
+	Walk(start, end []byte, reverse, snapshot bool, fn func(k, v []byte) error) error
+	Close()
+}
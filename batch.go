@@ -0,0 +1,76 @@
+package ezdb
+
+import (
+	"strconv"
+)
+
+// batchOp is a single queued Put or Delete in a Batch.
+type batchOp struct {
+	del   bool
+	key   []byte
+	value []byte // nil when del is true
+}
+
+// Batch accumulates a group of Put / Delete operations which are applied
+// to the database atomically by DB.Write() / DB.WriteSync(). A Batch is
+// backend-agnostic: each backend translates it into its own native write
+// batch type when it's written.
+type Batch struct {
+	ops []batchOp
+}
+
+// NewBatch creates a new empty Batch.
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Close releases the resources held by the batch. A Batch holds no
+// backend resources (each backend builds its native batch only while
+// writing), so Close is a no-op.
+func (b *Batch) Close() {}
+
+// Put queues a key / value pair to be written.
+func (b *Batch) Put(key, value []byte) {
+	b.ops = append(b.ops, batchOp{key: key, value: value})
+}
+
+// PutStr queues a string key / value pair to be written.
+func (b *Batch) PutStr(key, value string) {
+	b.Put([]byte(key), []byte(value))
+}
+
+// PutInt64 queues an int64 value stored as string. It should be used with DB.GetInt64().
+func (b *Batch) PutInt64(key string, value int64) {
+	b.PutStr(key, strconv.FormatInt(value, 10))
+}
+
+// PutUint64 queues a uint64 value stored as string. It should be used with DB.GetUint64().
+func (b *Batch) PutUint64(key string, value uint64) {
+	b.PutStr(key, strconv.FormatUint(value, 10))
+}
+
+// Delete queues a key to be deleted.
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{del: true, key: key})
+}
+
+// DeleteStr queues a string key to be deleted.
+func (b *Batch) DeleteStr(key string) {
+	b.Delete([]byte(key))
+}
+
+// Clear removes all queued operations from the batch.
+func (b *Batch) Clear() {
+	b.ops = b.ops[:0]
+}
+
+// Write commits the batch atomically using the db's default write options.
+func (db *DB) Write(b *Batch) (err error) {
+	return db.b.Write(b)
+}
+
+// WriteSync commits the batch atomically and waits for the write to be
+// flushed to disk before returning, like PutSync / DeleteSync.
+func (db *DB) WriteSync(b *Batch) (err error) {
+	return db.b.WriteSync(b)
+}
@@ -2,11 +2,8 @@ package ezdb
 
 import (
 	"errors"
-	"fmt"
 	"os"
 	"strconv"
-
-	"github.com/jmhodges/levigo"
 )
 
 var (
@@ -18,13 +15,10 @@ var (
 	errKeyNotExists                   = "key does not exist" // Key not exists error message.
 )
 
-// DB is a wrapper of levigo.DB.
+// DB wraps a leveldb database. It's backed by one of the supported
+// backends (see BackendKind), chosen when the DB is opened.
 type DB struct {
-	LevigoDB *levigo.DB           // Instance of levigo.DB
-	ro       *levigo.ReadOptions  // Read options for Get() of leveldb.
-	roIt     *levigo.ReadOptions  // Read options for itarators of leveldb.
-	wo       *levigo.WriteOptions // Write options for Put() of leveldb.
-	cache    *levigo.Cache        // Cache of leveldb.
+	b backend
 }
 
 // GoThroughProcessor provides the interface to process leveldb record while go through the leveldb database.
@@ -32,88 +26,39 @@ type GoThroughProcessor interface {
 	Process(k, v string) error
 }
 
-// Open opens a leveldb database.
-func Open(dbPath string, cacheSize int) (db *DB, err error) {
-	db = new(DB)
-
-	if DEBUG {
-		fmt.Printf("Open(): dbPath = %v, cacheSize = %v\n", dbPath, cacheSize)
-	}
-
-	if db.cache = levigo.NewLRUCache(cacheSize); db.cache == nil {
-		err = errors.New("levigo.NewLRUCache() == nil")
-		if DEBUG {
-			fmt.Println(err)
-		}
-		return nil, err
-	}
-	opts := levigo.NewOptions()
-	opts.SetCache(db.cache)
-	opts.SetCreateIfMissing(true)
-
-	if err = os.MkdirAll(dbPath, defDBFolderPermission); err != nil {
-		if DEBUG {
-			fmt.Printf("os.MkDirAll(%v, %v) err: %v\n", dbPath, defDBFolderPermission, err)
-		}
-		return nil, err
-	}
-
-	if db.LevigoDB, err = levigo.Open(dbPath, opts); err != nil {
-		if DEBUG {
-			fmt.Println(err)
-		}
-		return nil, err
-	}
-
-	db.ro = levigo.NewReadOptions()
-	db.roIt = levigo.NewReadOptions()
-	db.roIt.SetFillCache(false)
-	db.wo = levigo.NewWriteOptions()
-
-	return db, err
-}
-
 // Close closes the leveldb database after use.
 func (db *DB) Close() {
-	if db == nil {
+	if db == nil || db.b == nil {
 		return
 	}
-
-	if db.roIt != nil {
-		db.roIt.Close()
-	}
-
-	if db.ro != nil {
-		db.ro.Close()
-	}
-
-	if db.wo != nil {
-		db.wo.Close()
-	}
-
-	if db.LevigoDB != nil {
-		db.LevigoDB.Close()
-	}
-	// delete cache AFTER close leveldb or it will hang.
-	// See cache in http://leveldb.googlecode.com/svn/trunk/doc/index.html
-	if db.cache != nil {
-		db.cache.Close()
-	}
+	db.b.Close()
 }
 
-// Put is a wrapper for levigo.DB.Put().
+// Put puts the key / value pair into the database.
 func (db *DB) Put(key, value []byte) (err error) {
-	return db.LevigoDB.Put(db.wo, key, value)
+	return db.b.Put(key, value)
+}
+
+// PutSync is like Put but waits for the write to be flushed to disk
+// before returning.
+func (db *DB) PutSync(key, value []byte) (err error) {
+	return db.b.PutSync(key, value)
 }
 
-// Get is a wrapper for levigo.DB.Get().
+// Get gets the value of the key from the database.
 func (db *DB) Get(key []byte) (value []byte, err error) {
-	return db.LevigoDB.Get(db.ro, key)
+	return db.b.Get(key)
 }
 
-// Delete is a wrapper for levigo.DB.Delete()
+// Delete deletes the key from the database.
 func (db *DB) Delete(key []byte) (err error) {
-	return db.LevigoDB.Delete(db.wo, key)
+	return db.b.Delete(key)
+}
+
+// DeleteSync is like Delete but waits for the write to be flushed to
+// disk before returning.
+func (db *DB) DeleteSync(key []byte) (err error) {
+	return db.b.DeleteSync(key)
 }
 
 // PutStr puts the key / value as string value.
@@ -178,49 +123,17 @@ func (db *DB) DeleteStr(key string) (err error) {
 	return db.Delete([]byte(key))
 }
 
-// NewIterator creates a new iterator of levigo.
-func (db *DB) NewIterator() *levigo.Iterator {
-	return db.LevigoDB.NewIterator(db.roIt)
-}
-
-// IsIteratorValidForGoThrough checks if current iterator is valid while go through the db.
-func IsIteratorValidForGoThrough(it *levigo.Iterator, keyEnd string) bool {
-	if keyEnd != "" {
-		return it.Valid() && string(it.Key()) <= keyEnd
-	}
-	return it.Valid()
-}
-
 // GoThrough goes through the leveldb db and call the GoThroughProcessor.Process() to process data.
 func (db *DB) GoThrough(keyStart, keyEnd string, processor GoThroughProcessor) (err error) {
-	it := db.NewIterator()
-	defer it.Close()
-
+	var start, end []byte
 	if keyStart != "" {
-		it.Seek([]byte(keyStart))
-	} else {
-		it.SeekToFirst()
+		start = []byte(keyStart)
 	}
-
-	k := ""
-	v := ""
-	for ; IsIteratorValidForGoThrough(it, keyEnd); it.Next() {
-		k = string(it.Key())
-		v = string(it.Value())
-		if err = processor.Process(k, v); err != nil {
-			if DEBUG {
-				fmt.Printf("processor.Process(%v, %v) err: %v\n", k, v, err)
-			}
-			return err
-		}
-	}
-
-	if err := it.GetError(); err != nil {
-		if DEBUG {
-			fmt.Printf("it.GetError(): %s\n", err)
-		}
-		return err
+	if keyEnd != "" {
+		end = []byte(keyEnd)
 	}
 
-	return nil
+	return db.Range(start, end, func(k, v []byte) error {
+		return processor.Process(string(k), string(v))
+	})
 }
@@ -0,0 +1,249 @@
+package ezdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	goerrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// goLevelDBBackend backs a DB with the pure Go github.com/syndtr/goleveldb/leveldb
+// implementation. It needs no cgo and can recover automatically from a
+// corrupted database directory.
+type goLevelDBBackend struct {
+	db *leveldb.DB
+	wo *opt.WriteOptions // Write options for Put() of leveldb.
+	wS *opt.WriteOptions // Write options with sync enabled for PutSync() / WriteSync().
+}
+
+// openGoLevelDBBackend opens a leveldb database using goleveldb. If the
+// initial open reports the database directory is corrupted, it
+// automatically retries with leveldb.RecoverFile().
+func openGoLevelDBBackend(dbPath string, opts Options) (b *goLevelDBBackend, err error) {
+	b = new(goLevelDBBackend)
+
+	cacheSize := opts.CacheCapacity
+	if cacheSize <= 0 {
+		cacheSize = defCacheSize
+	}
+
+	if DEBUG {
+		fmt.Printf("openGoLevelDBBackend(): dbPath = %v, opts = %+v\n", dbPath, opts)
+	}
+
+	if opts.MustExist {
+		if _, statErr := os.Stat(dbPath); statErr != nil {
+			if DEBUG {
+				fmt.Printf("os.Stat(%v) err: %v\n", dbPath, statErr)
+			}
+			return nil, statErr
+		}
+	}
+
+	o := &opt.Options{
+		BlockCacheCapacity: cacheSize,
+		ErrorIfMissing:     opts.MustExist,
+		Strict:             opt.NoStrict,
+	}
+	if opts.ParanoidChecks {
+		o.Strict = opt.StrictAll
+	}
+	if opts.WriteBufferSize > 0 {
+		o.WriteBuffer = opts.WriteBufferSize
+	}
+	if opts.MaxOpenFiles > 0 {
+		o.OpenFilesCacheCapacity = opts.MaxOpenFiles
+	}
+	if opts.BlockSize > 0 {
+		o.BlockSize = opts.BlockSize
+	}
+	if opts.BloomFilterBitsPerKey > 0 {
+		o.Filter = filter.NewBloomFilter(opts.BloomFilterBitsPerKey)
+	}
+	if opts.Compression {
+		o.Compression = opt.SnappyCompression
+	} else {
+		o.Compression = opt.NoCompression
+	}
+
+	b.db, err = leveldb.OpenFile(dbPath, o)
+	if goerrors.IsCorrupted(err) {
+		if DEBUG {
+			fmt.Printf("openGoLevelDBBackend(): %v is corrupted, recovering\n", dbPath)
+		}
+		b.db, err = leveldb.RecoverFile(dbPath, o)
+	}
+	if err != nil {
+		if DEBUG {
+			fmt.Println(err)
+		}
+		return nil, err
+	}
+
+	b.wo = &opt.WriteOptions{}
+	b.wS = &opt.WriteOptions{Sync: true}
+
+	return b, nil
+}
+
+// Close closes the leveldb database after use.
+func (b *goLevelDBBackend) Close() {
+	if b == nil || b.db == nil {
+		return
+	}
+	b.db.Close()
+}
+
+// Put puts the key / value pair into the database.
+func (b *goLevelDBBackend) Put(key, value []byte) (err error) {
+	return b.db.Put(key, value, b.wo)
+}
+
+// PutSync is like Put but waits for the write to be flushed to disk before returning.
+func (b *goLevelDBBackend) PutSync(key, value []byte) (err error) {
+	return b.db.Put(key, value, b.wS)
+}
+
+// Get gets the value of the key from the database.
+func (b *goLevelDBBackend) Get(key []byte) (value []byte, err error) {
+	value, err = b.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Delete deletes the key from the database.
+func (b *goLevelDBBackend) Delete(key []byte) (err error) {
+	return b.db.Delete(key, b.wo)
+}
+
+// DeleteSync is like Delete but waits for the write to be flushed to disk before returning.
+func (b *goLevelDBBackend) DeleteSync(key []byte) (err error) {
+	return b.db.Delete(key, b.wS)
+}
+
+// goLevelDBBatch converts a backend-agnostic Batch into a leveldb.Batch.
+func goLevelDBBatch(batch *Batch) *leveldb.Batch {
+	lb := new(leveldb.Batch)
+	for _, op := range batch.ops {
+		if op.del {
+			lb.Delete(op.key)
+		} else {
+			lb.Put(op.key, op.value)
+		}
+	}
+	return lb
+}
+
+// Write commits the batch atomically using the default write options.
+func (b *goLevelDBBackend) Write(batch *Batch) (err error) {
+	return b.db.Write(goLevelDBBatch(batch), b.wo)
+}
+
+// WriteSync commits the batch atomically and waits for the write to be
+// flushed to disk before returning.
+func (b *goLevelDBBackend) WriteSync(batch *Batch) (err error) {
+	return b.db.Write(goLevelDBBatch(batch), b.wS)
+}
+
+// Walk iterates over the database in the given direction, optionally
+// pinning a snapshot for the duration of the walk so it doesn't observe
+// writes made after it started.
+func (b *goLevelDBBackend) Walk(start, end []byte, reverse, snapshot bool, fn func(k, v []byte) error) (err error) {
+	it, releaseSnap, err := b.newIterator(snapshot)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+	defer releaseSnap()
+
+	if reverse {
+		if end != nil {
+			if !it.Seek(end) {
+				it.Last()
+			} else if bytes.Compare(it.Key(), end) > 0 {
+				it.Prev()
+			}
+		} else {
+			it.Last()
+		}
+		for ; it.Valid(); it.Prev() {
+			k := it.Key()
+			if start != nil && bytes.Compare(k, start) < 0 {
+				break
+			}
+			if err = fn(k, it.Value()); err != nil {
+				break
+			}
+		}
+	} else {
+		if start != nil {
+			it.Seek(start)
+		} else {
+			it.First()
+		}
+		for ; it.Valid(); it.Next() {
+			k := it.Key()
+			if end != nil && bytes.Compare(k, end) > 0 {
+				break
+			}
+			if err = fn(k, it.Value()); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		if err == errStopWalk {
+			return nil
+		}
+		if DEBUG {
+			fmt.Printf("goLevelDBBackend.Walk(): fn err: %v\n", err)
+		}
+		return err
+	}
+
+	if err = it.Error(); err != nil {
+		if DEBUG {
+			fmt.Printf("it.Error(): %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// newIterator returns an iterator over the full keyspace, optionally
+// pinned to a fresh snapshot. The returned release func is never nil; on
+// error it is a no-op.
+func (b *goLevelDBBackend) newIterator(snapshot bool) (it iteratorLike, release func(), err error) {
+	if !snapshot {
+		return b.db.NewIterator(nil, nil), func() {}, nil
+	}
+
+	snap, err := b.db.GetSnapshot()
+	if err != nil {
+		return nil, func() {}, err
+	}
+	return snap.NewIterator(nil, nil), snap.Release, nil
+}
+
+// iteratorLike is the subset of the goleveldb iterator.Iterator interface
+// used by Walk, shared by *leveldb.DB and *leveldb.Snapshot iterators.
+type iteratorLike interface {
+	Valid() bool
+	First() bool
+	Last() bool
+	Seek(key []byte) bool
+	Next() bool
+	Prev() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
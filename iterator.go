@@ -0,0 +1,60 @@
+package ezdb
+
+import (
+	"bytes"
+	"errors"
+)
+
+// errStopWalk is a sentinel error used internally to stop a walk early
+// without it being reported as a failure to the caller.
+var errStopWalk = errors.New("ezdb: stop walk")
+
+// Range iterates over all key / value pairs with start <= key <= end and
+// calls fn for each of them, in ascending key order. A nil start walks
+// from the first key; a nil end walks to the last key. Iteration stops
+// as soon as fn returns a non-nil error, and that error is returned by Range.
+func (db *DB) Range(start, end []byte, fn func(k, v []byte) error) (err error) {
+	return db.b.Walk(start, end, false, false, fn)
+}
+
+// RangeSnapshot is like Range, but walks a consistent snapshot of the
+// database taken at the start of the call, so concurrent writes made
+// while the walk is in progress are not observed.
+func (db *DB) RangeSnapshot(start, end []byte, fn func(k, v []byte) error) (err error) {
+	return db.b.Walk(start, end, false, true, fn)
+}
+
+// Reverse iterates over all key / value pairs with start <= key <= end and
+// calls fn for each of them, in descending key order.
+func (db *DB) Reverse(start, end []byte, fn func(k, v []byte) error) (err error) {
+	return db.b.Walk(start, end, true, false, fn)
+}
+
+// ReverseSnapshot is like Reverse, but walks a consistent snapshot of the
+// database taken at the start of the call.
+func (db *DB) ReverseSnapshot(start, end []byte, fn func(k, v []byte) error) (err error) {
+	return db.b.Walk(start, end, true, true, fn)
+}
+
+// Prefix iterates over all key / value pairs whose key starts with prefix
+// and calls fn for each of them, in ascending key order.
+func (db *DB) Prefix(prefix []byte, fn func(k, v []byte) error) (err error) {
+	return db.prefixWalk(prefix, false, fn)
+}
+
+// PrefixSnapshot is like Prefix, but walks a consistent snapshot of the
+// database taken at the start of the call.
+func (db *DB) PrefixSnapshot(prefix []byte, fn func(k, v []byte) error) (err error) {
+	return db.prefixWalk(prefix, true, fn)
+}
+
+// prefixWalk walks ascending from prefix and stops as soon as a key no
+// longer has prefix as its prefix.
+func (db *DB) prefixWalk(prefix []byte, snapshot bool, fn func(k, v []byte) error) (err error) {
+	return db.b.Walk(prefix, nil, false, snapshot, func(k, v []byte) error {
+		if !bytes.HasPrefix(k, prefix) {
+			return errStopWalk
+		}
+		return fn(k, v)
+	})
+}
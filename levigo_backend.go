@@ -0,0 +1,260 @@
+//go:build cgo
+// +build cgo
+
+package ezdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jmhodges/levigo"
+)
+
+// levigoBackend backs a DB with github.com/jmhodges/levigo.
+type levigoBackend struct {
+	db     *levigo.DB           // Instance of levigo.DB
+	ro     *levigo.ReadOptions  // Read options for Get() of leveldb.
+	roIt   *levigo.ReadOptions  // Read options for iterators of leveldb.
+	wo     *levigo.WriteOptions // Write options for Put() of leveldb.
+	woSync *levigo.WriteOptions // Write options with sync enabled for PutSync() / WriteSync().
+	cache  *levigo.Cache        // Cache of leveldb.
+
+	filterPolicy *levigo.FilterPolicy // Bloom filter policy, set when Options.BloomFilterBitsPerKey > 0.
+}
+
+// openLevigoBackend opens a leveldb database using the levigo cgo bindings.
+func openLevigoBackend(dbPath string, opts Options) (b *levigoBackend, err error) {
+	b = new(levigoBackend)
+
+	cacheSize := opts.CacheCapacity
+	if cacheSize <= 0 {
+		cacheSize = defCacheSize
+	}
+
+	if DEBUG {
+		fmt.Printf("openLevigoBackend(): dbPath = %v, opts = %+v\n", dbPath, opts)
+	}
+
+	if b.cache = levigo.NewLRUCache(cacheSize); b.cache == nil {
+		err = errors.New("levigo.NewLRUCache() == nil")
+		if DEBUG {
+			fmt.Println(err)
+		}
+		return nil, err
+	}
+
+	levigoOpts := levigo.NewOptions()
+	levigoOpts.SetCache(b.cache)
+	levigoOpts.SetCreateIfMissing(!opts.MustExist)
+	levigoOpts.SetParanoidChecks(opts.ParanoidChecks)
+
+	if opts.WriteBufferSize > 0 {
+		levigoOpts.SetWriteBufferSize(opts.WriteBufferSize)
+	}
+	if opts.MaxOpenFiles > 0 {
+		levigoOpts.SetMaxOpenFiles(opts.MaxOpenFiles)
+	}
+	if opts.BlockSize > 0 {
+		levigoOpts.SetBlockSize(opts.BlockSize)
+	}
+	if opts.BloomFilterBitsPerKey > 0 {
+		b.filterPolicy = levigo.NewBloomFilter(opts.BloomFilterBitsPerKey)
+		levigoOpts.SetFilterPolicy(b.filterPolicy)
+	}
+	if opts.Compression {
+		levigoOpts.SetCompression(levigo.SnappyCompression)
+	} else {
+		levigoOpts.SetCompression(levigo.NoCompression)
+	}
+
+	if !opts.MustExist {
+		if err = os.MkdirAll(dbPath, defDBFolderPermission); err != nil {
+			if DEBUG {
+				fmt.Printf("os.MkDirAll(%v, %v) err: %v\n", dbPath, defDBFolderPermission, err)
+			}
+			return nil, err
+		}
+	}
+
+	if b.db, err = levigo.Open(dbPath, levigoOpts); err != nil {
+		if DEBUG {
+			fmt.Println(err)
+		}
+		return nil, err
+	}
+
+	b.ro = levigo.NewReadOptions()
+	b.roIt = levigo.NewReadOptions()
+	b.roIt.SetFillCache(false)
+	b.wo = levigo.NewWriteOptions()
+	b.woSync = levigo.NewWriteOptions()
+	b.woSync.SetSync(true)
+
+	return b, nil
+}
+
+// Close closes the leveldb database after use.
+func (b *levigoBackend) Close() {
+	if b == nil {
+		return
+	}
+
+	if b.roIt != nil {
+		b.roIt.Close()
+	}
+
+	if b.ro != nil {
+		b.ro.Close()
+	}
+
+	if b.wo != nil {
+		b.wo.Close()
+	}
+
+	if b.woSync != nil {
+		b.woSync.Close()
+	}
+
+	if b.db != nil {
+		b.db.Close()
+	}
+	// delete cache AFTER close leveldb or it will hang.
+	// See cache in http://leveldb.googlecode.com/svn/trunk/doc/index.html
+	if b.cache != nil {
+		b.cache.Close()
+	}
+
+	if b.filterPolicy != nil {
+		b.filterPolicy.Close()
+	}
+}
+
+// Put is a wrapper for levigo.DB.Put().
+func (b *levigoBackend) Put(key, value []byte) (err error) {
+	return b.db.Put(b.wo, key, value)
+}
+
+// PutSync is like Put but waits for the write to be flushed to disk before returning.
+func (b *levigoBackend) PutSync(key, value []byte) (err error) {
+	return b.db.Put(b.woSync, key, value)
+}
+
+// Get is a wrapper for levigo.DB.Get().
+func (b *levigoBackend) Get(key []byte) (value []byte, err error) {
+	return b.db.Get(b.ro, key)
+}
+
+// Delete is a wrapper for levigo.DB.Delete().
+func (b *levigoBackend) Delete(key []byte) (err error) {
+	return b.db.Delete(b.wo, key)
+}
+
+// DeleteSync is like Delete but waits for the write to be flushed to disk before returning.
+func (b *levigoBackend) DeleteSync(key []byte) (err error) {
+	return b.db.Delete(b.woSync, key)
+}
+
+// levigoWriteBatch converts a backend-agnostic Batch into a levigo.WriteBatch.
+func levigoWriteBatch(batch *Batch) *levigo.WriteBatch {
+	wb := levigo.NewWriteBatch()
+	for _, op := range batch.ops {
+		if op.del {
+			wb.Delete(op.key)
+		} else {
+			wb.Put(op.key, op.value)
+		}
+	}
+	return wb
+}
+
+// Write commits the batch atomically using the default write options.
+func (b *levigoBackend) Write(batch *Batch) (err error) {
+	wb := levigoWriteBatch(batch)
+	defer wb.Close()
+	return b.db.Write(b.wo, wb)
+}
+
+// WriteSync commits the batch atomically and waits for the write to be
+// flushed to disk before returning.
+func (b *levigoBackend) WriteSync(batch *Batch) (err error) {
+	wb := levigoWriteBatch(batch)
+	defer wb.Close()
+	return b.db.Write(b.woSync, wb)
+}
+
+// Walk iterates over the database in the given direction, optionally
+// pinning a *levigo.Snapshot for the duration of the walk so it doesn't
+// observe writes made after it started.
+func (b *levigoBackend) Walk(start, end []byte, reverse, snapshot bool, fn func(k, v []byte) error) (err error) {
+	ro := b.roIt
+	if snapshot {
+		snap := b.db.NewSnapshot()
+		defer b.db.ReleaseSnapshot(snap)
+
+		ro = levigo.NewReadOptions()
+		defer ro.Close()
+		ro.SetFillCache(false)
+		ro.SetSnapshot(snap)
+	}
+
+	it := b.db.NewIterator(ro)
+	defer it.Close()
+
+	if reverse {
+		if end != nil {
+			it.Seek(end)
+			if !it.Valid() {
+				it.SeekToLast()
+			} else if bytes.Compare(it.Key(), end) > 0 {
+				it.Prev()
+			}
+		} else {
+			it.SeekToLast()
+		}
+		for ; it.Valid(); it.Prev() {
+			k := it.Key()
+			if start != nil && bytes.Compare(k, start) < 0 {
+				break
+			}
+			if err = fn(k, it.Value()); err != nil {
+				break
+			}
+		}
+	} else {
+		if start != nil {
+			it.Seek(start)
+		} else {
+			it.SeekToFirst()
+		}
+		for ; it.Valid(); it.Next() {
+			k := it.Key()
+			if end != nil && bytes.Compare(k, end) > 0 {
+				break
+			}
+			if err = fn(k, it.Value()); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		if err == errStopWalk {
+			return nil
+		}
+		if DEBUG {
+			fmt.Printf("levigoBackend.Walk(): fn err: %v\n", err)
+		}
+		return err
+	}
+
+	if err = it.GetError(); err != nil {
+		if DEBUG {
+			fmt.Printf("it.GetError(): %s\n", err)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !cgo
+// +build !cgo
+
+package ezdb
+
+import "errors"
+
+// openLevigoBackend is the stand-in used in builds without cgo, where
+// levigo_backend.go (which needs cgo and libleveldb) is excluded from the
+// build entirely. This keeps `go build` working with no C toolchain as
+// long as callers select BackendGoLevelDB; only opening BackendLevigo
+// (the default) fails, and only at runtime.
+func openLevigoBackend(dbPath string, opts Options) (backend, error) {
+	return nil, errors.New("ezdb: the levigo backend requires building with cgo enabled; open with Options.Backend = BackendGoLevelDB instead")
+}
@@ -0,0 +1,65 @@
+package ezdb
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+// PutObj gob-encodes v and stores it under key. It should be used with GetObj().
+func (db *DB) PutObj(key []byte, v interface{}) (err error) {
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	return db.Put(key, buf.Bytes())
+}
+
+// GetObj gets the value stored under key and gob-decodes it into v, which
+// must be a pointer to a value of the type that was stored with PutObj().
+func (db *DB) GetObj(key []byte, v interface{}) (err error) {
+	value, err := db.Get(key)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New(errKeyNotExists)
+	}
+	return gob.NewDecoder(bytes.NewReader(value)).Decode(v)
+}
+
+// PutJSON JSON-encodes v and stores it under key. It should be used with
+// GetJSON(), or read directly by non-Go readers of the database.
+func (db *DB) PutJSON(key []byte, v interface{}) (err error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, value)
+}
+
+// GetJSON gets the value stored under key and JSON-decodes it into v.
+func (db *DB) GetJSON(key []byte, v interface{}) (err error) {
+	value, err := db.Get(key)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return errors.New(errKeyNotExists)
+	}
+	return json.Unmarshal(value, v)
+}
+
+// RangeObj is like Range, but gob-decodes each value into proto (a pointer
+// to a prototype of the stored type) before calling fn with the raw key
+// and the decoded value. proto is reused and overwritten on every call, so
+// fn must copy out anything it needs to keep past its own invocation.
+func (db *DB) RangeObj(start, end []byte, proto interface{}, fn func(k []byte, v interface{}) error) (err error) {
+	return db.Range(start, end, func(k, v []byte) error {
+		if err := gob.NewDecoder(bytes.NewReader(v)).Decode(proto); err != nil {
+			return err
+		}
+		return fn(k, proto)
+	})
+}
@@ -0,0 +1,47 @@
+package ezdb
+
+// Options holds the tunable parameters used to open a leveldb database.
+// Zero values fall back to the same defaults Open() has always used,
+// except where noted below.
+type Options struct {
+	Backend               BackendKind // Storage engine to use. Defaults to BackendLevigo.
+	CacheCapacity         int         // LRU block cache size in bytes. Defaults to defCacheSize if <= 0.
+	WriteBufferSize       int         // Amount of data to build up in memory before writing to disk. 0 uses the backend's default.
+	MaxOpenFiles          int         // Number of open files the database can use at once. 0 uses the backend's default.
+	BlockSize             int         // Approximate size of user data packed per block. 0 uses the backend's default.
+	BloomFilterBitsPerKey int         // Bits per key for the bloom filter policy. 0 disables the bloom filter.
+	Compression           bool        // Enable snappy compression. Defaults to false (no compression).
+	ParanoidChecks        bool        // Make the database throw an error as soon as an internal corruption is detected.
+	MustExist             bool        // Fail Open() if the database does not already exist, instead of creating it.
+}
+
+// defOptions returns the Options used by Open(dbPath, cacheSize): cacheSize
+// as CacheCapacity and zero values for everything else, so snappy
+// compression is off.
+func defOptions(cacheSize int) Options {
+	return Options{CacheCapacity: cacheSize}
+}
+
+// Open opens a leveldb database using cacheSize as the LRU cache size,
+// and the same defaults OpenWithOptions() uses for everything else.
+func Open(dbPath string, cacheSize int) (db *DB, err error) {
+	return OpenWithOptions(dbPath, defOptions(cacheSize))
+}
+
+// OpenWithOptions opens a leveldb database with fine-grained tuning via
+// opts, using the backend named by opts.Backend.
+func OpenWithOptions(dbPath string, opts Options) (db *DB, err error) {
+	db = new(DB)
+
+	switch opts.Backend {
+	case BackendGoLevelDB:
+		db.b, err = openGoLevelDBBackend(dbPath, opts)
+	default:
+		db.b, err = openLevigoBackend(dbPath, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}